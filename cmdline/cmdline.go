@@ -0,0 +1,120 @@
+// Package cmdline implements the ':' commands the cmdline mode collects,
+// wiring them to the KeyManager, Layout and macro-register plumbing the
+// core, rc and macro packages otherwise only expose as a library.
+package cmdline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/bed/core"
+	"github.com/itchyny/bed/macro"
+	"github.com/itchyny/bed/rc"
+)
+
+// Executor runs ':' commands against the running editor's state.
+type Executor struct {
+	// KeyManagers is consulted by :map and :unmap to find the KeyManager
+	// for a given mode name.
+	KeyManagers map[core.Mode]*core.KeyManager
+
+	// Layout is grown by :vsplit, :split and :diff.
+	Layout *core.Layout
+}
+
+// Execute parses and runs a single cmdline command line, without the
+// leading ':'. Most commands return an empty string; :registers returns
+// text meant to be shown to the user.
+func (e *Executor) Execute(line string) (string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	switch fields[0] {
+	case "map":
+		return "", e.execMap(fields[1:])
+	case "unmap":
+		return "", e.execUnmap(fields[1:])
+	case "vsplit":
+		return "", e.execOpen(fields[1:], true, false)
+	case "split":
+		return "", e.execOpen(fields[1:], false, false)
+	case "diff":
+		return "", e.execOpen(fields[1:], true, true)
+	case "registers":
+		return e.execRegisters()
+	default:
+		return "", fmt.Errorf("unknown command: %s", fields[0])
+	}
+}
+
+// execOpen implements ":vsplit file" / ":split file" / ":diff file",
+// opening file into a new window alongside the currently focused one.
+func (e *Executor) execOpen(args []string, vertical, diff bool) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: vsplit/split/diff filename")
+	}
+	w, err := core.NewWindow(args[0], e.Layout.Focused().Width())
+	if err != nil {
+		return fmt.Errorf("open %s: %w", args[0], err)
+	}
+	if diff {
+		e.Layout.Diff(w)
+	} else {
+		e.Layout.Split(w, vertical)
+	}
+	return nil
+}
+
+// execMap implements ":map mode keys event", e.g. ":map normal f EventJumpTo"
+// or ":map normal g,g EventPageTop" for a two-keystroke binding.
+func (e *Executor) execMap(args []string) error {
+	b, err := rc.ParseLine(strings.Join(args, " "))
+	if err != nil {
+		return fmt.Errorf("map: %w", err)
+	}
+	km, ok := e.KeyManagers[b.Mode]
+	if !ok {
+		return fmt.Errorf("map: no key manager for mode %v", b.Mode)
+	}
+	if km.WouldShadow(b.Keys...) {
+		return fmt.Errorf("map: %v would shadow an existing binding", b.Keys)
+	}
+	km.Replace(b.Event, b.Keys...)
+	return nil
+}
+
+// execUnmap implements ":unmap mode keys", e.g. ":unmap normal +" to remove
+// the confusingly-overlapping "+"/"-" increment bindings.
+func (e *Executor) execUnmap(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("unmap: usage: unmap mode keys")
+	}
+	mode, ok := rc.ModeByName(args[0])
+	if !ok {
+		return fmt.Errorf("unmap: unknown mode %q", args[0])
+	}
+	km, ok := e.KeyManagers[mode]
+	if !ok {
+		return fmt.Errorf("unmap: no key manager for mode %v", mode)
+	}
+	parts := strings.Split(args[1], ",")
+	keys := make([]core.Key, len(parts))
+	for i, k := range parts {
+		keys[i] = core.Key(k)
+	}
+	km.Unregister(keys...)
+	return nil
+}
+
+// execRegisters implements ":registers", listing the recorded macro
+// registers. Only the Normal-mode KeyManager intercepts "q"/"@" for macro
+// recording/replay (see NewMacroKeyManager), so it is the only one that
+// ever has registers to list.
+func (e *Executor) execRegisters() (string, error) {
+	km, ok := e.KeyManagers[core.ModeNormal]
+	if !ok {
+		return "", fmt.Errorf("registers: no key manager for normal mode")
+	}
+	return macro.List(km), nil
+}
@@ -0,0 +1,35 @@
+package cmdline
+
+import (
+	"testing"
+
+	"github.com/itchyny/bed/core"
+)
+
+func TestExecuteMapAndUnmap(t *testing.T) {
+	km := core.NewKeyManager(true)
+	km.Register(core.EventQuit, "z")
+	e := &Executor{KeyManagers: map[core.Mode]*core.KeyManager{core.ModeNormal: km}}
+
+	if _, err := e.Execute("map normal g,g EventPageTop"); err != nil {
+		t.Fatalf("map: %v", err)
+	}
+	km.Press("g")
+	if ev := km.Press("g"); ev.Type != core.EventPageTop {
+		t.Errorf("got %v, want EventPageTop after :map", ev.Type)
+	}
+
+	if _, err := e.Execute("unmap normal z"); err != nil {
+		t.Fatalf("unmap: %v", err)
+	}
+	if ev := km.Press("z"); ev.Type != core.EventNop {
+		t.Errorf("got %v, want EventNop after :unmap", ev.Type)
+	}
+}
+
+func TestExecuteUnknownCommand(t *testing.T) {
+	e := &Executor{}
+	if _, err := e.Execute("bogus"); err == nil {
+		t.Error("expected an error for an unknown command")
+	}
+}
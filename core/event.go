@@ -0,0 +1,82 @@
+package core
+
+//go:generate stringer -type=EventType
+
+// EventType represents the kind of event.
+type EventType int
+
+// Event types.
+const (
+	EventNop EventType = iota
+	EventQuit
+
+	EventCursorUp
+	EventCursorDown
+	EventCursorLeft
+	EventCursorRight
+	EventCursorPrev
+	EventCursorNext
+	EventCursorHead
+	EventCursorEnd
+
+	EventScrollUp
+	EventScrollDown
+	EventPageUp
+	EventPageDown
+	EventPageUpHalf
+	EventPageDownHalf
+	EventPageTop
+	EventPageEnd
+
+	EventJumpTo
+	EventJumpBack
+	EventCursorGoto
+
+	EventDeleteByte
+	EventDeletePrevByte
+	EventIncrement
+	EventDecrement
+
+	EventStartInsert
+	EventStartInsertHead
+	EventStartAppend
+	EventStartAppendEnd
+	EventStartReplaceByte
+	EventStartReplace
+	EventExitInsert
+
+	EventBackspace
+	EventDelete
+
+	EventStartVisual
+	EventStartVisualLine
+	EventExitVisual
+	EventYank
+	EventDeleteSelection
+	EventReplaceSelection
+	EventPasteAfter
+	EventPasteBefore
+
+	EventFocusNext
+	EventFocusPrev
+	EventReplayMacro
+
+	EventStartCmdline
+	EventSpaceCmdline
+	EventBackspaceCmdline
+	EventDeleteCmdline
+	EventDeleteWordCmdline
+	EventClearToHeadCmdline
+	EventClearCmdline
+	EventExitCmdline
+	EventExecuteCmdline
+)
+
+// Event represents an event corresponding to a key.
+type Event struct {
+	Type     EventType
+	Count    int64
+	Key      Key
+	MouseRow int
+	MouseCol int
+}
@@ -0,0 +1,20 @@
+package core
+
+// eventTypeByName is the reverse of EventType.String, built once from it so
+// the two can never drift apart. It lets the bedrc parser resolve a
+// user-supplied name like "EventJumpTo" back to the EventType it names.
+var eventTypeByName = func() map[string]EventType {
+	m := make(map[string]EventType, len(_EventType_index)-1)
+	for i := 0; i < len(_EventType_index)-1; i++ {
+		et := EventType(i)
+		m[et.String()] = et
+	}
+	return m
+}()
+
+// EventTypeFromString resolves an event name to its EventType, reporting
+// false if name does not match any known EventType.
+func EventTypeFromString(name string) (EventType, bool) {
+	et, ok := eventTypeByName[name]
+	return et, ok
+}
@@ -38,6 +38,14 @@ type KeyManager struct {
 	keys      []Key
 	keyEvents []keyEvent
 	count     bool
+	macro     bool // whether "q"/"@" are intercepted for macro recording/replay
+
+	pending   Key // "q" or "@" while waiting for the register key that follows it
+	recording *[]Key
+	recordReg Key
+	lastReg   Key
+	registers map[Key][]Key
+	depth     int
 }
 
 // NewKeyManager creates a new KeyManager.
@@ -45,13 +53,113 @@ func NewKeyManager(count bool) *KeyManager {
 	return &KeyManager{count: count}
 }
 
+// NewMacroKeyManager creates a new KeyManager that also intercepts "q"/"@"
+// for macro recording and replay. Only one KeyManager per editor should be
+// built this way: macro state (registers, in-progress recording) lives on
+// the instance itself, so Normal mode is the one that owns it — Visual and
+// VisualLine get their own KeyManager (see defaultKeyManagers) and must not
+// also intercept "q", or a "q" pressed there would start an independent,
+// invisible recording that "q"/"@" typed back in Normal mode can neither
+// see nor stop, while swallowing whatever motion key the user meant to
+// press next.
+func NewMacroKeyManager(count bool) *KeyManager {
+	return &KeyManager{count: count, macro: true}
+}
+
 // Register adds a new key mapping.
 func (km *KeyManager) Register(event EventType, keys ...Key) {
 	km.keyEvents = append(km.keyEvents, keyEvent{keys, event})
 }
 
+// Unregister removes the mapping bound to the given key sequence, if any.
+func (km *KeyManager) Unregister(keys ...Key) {
+	for i, ke := range km.keyEvents {
+		if equalKeys(ke.keys, keys) {
+			km.keyEvents = append(km.keyEvents[:i], km.keyEvents[i+1:]...)
+			return
+		}
+	}
+}
+
+// Replace rebinds event to a new key sequence. Any mapping previously
+// registered for event is removed first, and so is any mapping already
+// bound to the exact same keys, whatever event it points at — otherwise
+// rebinding an already-bound key to a new event (e.g. taking "+" off of
+// EventIncrement) would leave the old entry in keyEvents, and since Press
+// returns on the first match, it would keep winning over the new one,
+// leaving it permanently unreachable.
+func (km *KeyManager) Replace(event EventType, keys ...Key) {
+	kept := km.keyEvents[:0:0]
+	for _, ke := range km.keyEvents {
+		if ke.event == event || equalKeys(ke.keys, keys) {
+			continue
+		}
+		kept = append(kept, ke)
+	}
+	km.keyEvents = kept
+	km.Register(event, keys...)
+}
+
+// WouldShadow reports whether registering keys would conflict with an
+// existing binding: either keys is a prefix of a longer binding already
+// registered (which would then become unreachable), or an existing
+// shorter binding is a prefix of keys (making keys itself unreachable).
+// Equal-length sequences never shadow one another this way — binding the
+// same keys to a different event doesn't hide anything, it's simply
+// replaced by Replace, which strips the old entry for those exact keys.
+func (km *KeyManager) WouldShadow(keys ...Key) bool {
+	for _, ke := range km.keyEvents {
+		a, b := ke.keys, []Key(keys)
+		if len(a) > len(b) {
+			a, b = b, a
+		}
+		if len(a) == 0 || len(a) == len(b) {
+			continue
+		}
+		prefix := true
+		for i := range a {
+			if a[i] != b[i] {
+				prefix = false
+				break
+			}
+		}
+		if prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func equalKeys(a, b []Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Press checks the new key down event.
 func (km *KeyManager) Press(k Key) Event {
+	return km.press(k, 0, 0)
+}
+
+// PressMouse checks a new mouse event, same as Press but also carries the
+// row and column the pointer was at so cursor-positioning events (like
+// EventCursorGoto) can compute the byte the user clicked on.
+func (km *KeyManager) PressMouse(k Key, row, col int) Event {
+	return km.press(k, row, col)
+}
+
+func (km *KeyManager) press(k Key, row, col int) Event {
+	if km.macro {
+		if ev, handled := km.pressMacro(k); handled {
+			return ev
+		}
+	}
 	km.keys = append(km.keys, k)
 	for i := 0; i < len(km.keys); i++ {
 		keys := km.keys[i:]
@@ -74,7 +182,7 @@ func (km *KeyManager) Press(k Key) Event {
 				return Event{Type: EventNop}
 			case keysEq:
 				km.keys = nil
-				return Event{Type: ke.event, Count: count}
+				return Event{Type: ke.event, Count: count, MouseRow: row, MouseCol: col}
 			}
 		}
 	}
@@ -82,9 +190,13 @@ func (km *KeyManager) Press(k Key) Event {
 	return Event{Type: EventNop}
 }
 
-func defaultKeyManagers() map[Mode]*KeyManager {
-	kms := make(map[Mode]*KeyManager)
-	km := NewKeyManager(true)
+// registerMotions registers the bindings common to every mode that walks
+// the cursor around the buffer: movement, paging, scrolling, jumps, single
+// byte edits, mouse input and window focus switching. Normal and visual
+// mode each get their own KeyManager built from this shared base, since
+// while the motions are identical, the keys layered on top of them are
+// not: y/d/c only make sense once a visual selection exists.
+func registerMotions(km *KeyManager) {
 	km.Register(EventQuit, "Z", "Q")
 	km.Register(EventCursorUp, "up")
 	km.Register(EventCursorDown, "down")
@@ -120,16 +232,49 @@ func defaultKeyManagers() map[Mode]*KeyManager {
 	km.Register(EventDecrement, "c-x")
 	km.Register(EventDecrement, "-")
 
+	km.Register(EventScrollUp, "wheel-up")
+	km.Register(EventScrollDown, "wheel-down")
+	km.Register(EventCursorGoto, "mouse-left")
+
+	km.Register(EventPasteAfter, "p")
+	km.Register(EventPasteBefore, "P")
+
+	km.Register(EventFocusNext, "c-w", "w")
+	km.Register(EventFocusNext, "c-w", "l")
+	km.Register(EventFocusPrev, "c-w", "h")
+}
+
+func defaultKeyManagers() map[Mode]*KeyManager {
+	kms := make(map[Mode]*KeyManager)
+
+	km := NewMacroKeyManager(true)
+	registerMotions(km)
 	km.Register(EventStartInsert, "i")
 	km.Register(EventStartInsertHead, "I")
 	km.Register(EventStartAppend, "a")
 	km.Register(EventStartAppendEnd, "A")
 	km.Register(EventStartReplaceByte, "r")
 	km.Register(EventStartReplace, "R")
-
 	km.Register(EventStartCmdline, ":")
+	km.Register(EventStartVisual, "v")
+	km.Register(EventStartVisualLine, "V")
 	kms[ModeNormal] = km
 
+	// Visual and visual-line mode get their own KeyManager: the motions are
+	// shared with Normal mode (so movement extends the selection, since
+	// Window keeps selStart fixed while the cursor moves), but y/d/c only
+	// operate on a selection and must not be reachable from Normal mode,
+	// where selStart is never set and defaults to 0 — pressing them there
+	// would silently cut from byte 0 to the cursor.
+	km = NewKeyManager(true)
+	registerMotions(km)
+	km.Register(EventExitVisual, "escape")
+	km.Register(EventYank, "y")
+	km.Register(EventDeleteSelection, "d")
+	km.Register(EventReplaceSelection, "c")
+	kms[ModeVisual] = km
+	kms[ModeVisualLine] = km
+
 	km = NewKeyManager(false)
 	km.Register(EventExitInsert, "escape")
 	km.Register(EventExitInsert, "c-c")
@@ -0,0 +1,70 @@
+package core
+
+import "testing"
+
+func TestKeyManagerPressFirstMatchWins(t *testing.T) {
+	km := NewKeyManager(false)
+	km.Register(EventCursorDown, "g", "g")
+	km.Register(EventPageTop, "g", "g")
+
+	km.Press("g")
+	if ev := km.Press("g"); ev.Type != EventCursorDown {
+		t.Errorf("got %v, want the first-registered binding EventCursorDown", ev.Type)
+	}
+}
+
+func TestKeyManagerUnregister(t *testing.T) {
+	km := NewKeyManager(false)
+	km.Register(EventQuit, "Z", "Q")
+	km.Unregister("Z", "Q")
+
+	km.Press("Z")
+	if ev := km.Press("Q"); ev.Type != EventNop {
+		t.Errorf("got %v, want EventNop after Unregister", ev.Type)
+	}
+}
+
+func TestKeyManagerReplaceDropsStaleKeyBinding(t *testing.T) {
+	km := NewKeyManager(false)
+	km.Register(EventIncrement, "+")
+	// Rebinding "+" to a different event must make the old EventIncrement
+	// binding unreachable, not merely add a second entry that Press would
+	// never reach because the stale one still matches first.
+	km.Replace(EventDecrement, "+")
+
+	if ev := km.Press("+"); ev.Type != EventDecrement {
+		t.Errorf("got %v, want EventDecrement", ev.Type)
+	}
+}
+
+func TestKeyManagerReplaceDropsStaleEventBinding(t *testing.T) {
+	km := NewKeyManager(false)
+	km.Register(EventIncrement, "+")
+	km.Replace(EventIncrement, "c-a")
+
+	km.Press("+")
+	if ev := km.Press("+"); ev.Type != EventNop {
+		t.Errorf("got %v, want EventNop: the old \"+\" binding for EventIncrement should be gone", ev.Type)
+	}
+	if ev := km.Press("c-a"); ev.Type != EventIncrement {
+		t.Errorf("got %v, want EventIncrement via the new binding", ev.Type)
+	}
+}
+
+func TestKeyManagerWouldShadow(t *testing.T) {
+	km := NewKeyManager(false)
+	km.Register(EventPageTop, "g", "g")
+
+	if !km.WouldShadow("g") {
+		t.Error("a shorter prefix of an existing binding should shadow it")
+	}
+	if !km.WouldShadow("g", "g", "g") {
+		t.Error("a longer sequence with an existing binding as its prefix should be shadowed")
+	}
+	if km.WouldShadow("g", "g") {
+		t.Error("binding the exact same keys again is a Replace, not a shadow")
+	}
+	if km.WouldShadow("x") {
+		t.Error("an unrelated key sequence should not shadow anything")
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+// split describes how the windows of a Layout are arranged on screen.
+type split int
+
+const (
+	splitNone split = iota
+	splitHorizontal
+	splitVertical
+)
+
+// Layout arranges one or more windows on screen. A freshly created Layout
+// holds a single window and behaves exactly as before; Split and Diff grow
+// it into a composable multi-window layout.
+type Layout struct {
+	windows []*Window
+	rects   []Rect
+	split   split
+	focus   int
+	diff    bool
+}
+
+// NewLayout creates a layout holding a single window.
+func NewLayout(w *Window) *Layout {
+	return &Layout{windows: []*Window{w}}
+}
+
+// Split adds w to the layout, arranging it beside the existing windows when
+// vertical is true (:vsplit) or below them otherwise (:split), and gives
+// it focus.
+func (l *Layout) Split(w *Window, vertical bool) {
+	if vertical {
+		l.split = splitVertical
+	} else {
+		l.split = splitHorizontal
+	}
+	l.windows = append(l.windows, w)
+	l.focus = len(l.windows) - 1
+}
+
+// Diff adds w to the layout as a side-by-side diff target (:diff): the
+// windows scroll in lockstep and State.DiffMask is filled in by States.
+func (l *Layout) Diff(w *Window) {
+	l.Split(w, true)
+	l.diff = true
+}
+
+// FocusNext focuses the next window, wrapping around.
+func (l *Layout) FocusNext() {
+	l.focus = (l.focus + 1) % len(l.windows)
+}
+
+// FocusPrev focuses the previous window, wrapping around.
+func (l *Layout) FocusPrev() {
+	l.focus = (l.focus - 1 + len(l.windows)) % len(l.windows)
+}
+
+// Focused returns the window that currently receives key events.
+func (l *Layout) Focused() *Window {
+	return l.windows[l.focus]
+}
+
+// Resize recomputes every window's viewport rectangle from the terminal
+// size, splitting the available space proportionally the way fzf's
+// calculateMargins divides margins among panes; the last pane absorbs any
+// remainder so the rectangles always sum exactly to width/height.
+func (l *Layout) Resize(width, height int) {
+	n := len(l.windows)
+	l.rects = make([]Rect, n)
+	for i, w := range l.windows {
+		var r Rect
+		switch l.split {
+		case splitVertical:
+			colWidth := width / n
+			r = Rect{X: i * colWidth, Y: 0, Width: colWidth, Height: height}
+			if i == n-1 {
+				r.Width = width - r.X
+			}
+		case splitHorizontal:
+			rowHeight := height / n
+			r = Rect{X: 0, Y: i * rowHeight, Width: width, Height: rowHeight}
+			if i == n-1 {
+				r.Height = height - r.Y
+			}
+		default:
+			r = Rect{X: 0, Y: 0, Width: width, Height: height}
+		}
+		l.rects[i] = r
+		w.resize(r.Height)
+	}
+	if l.diff {
+		l.lockScroll()
+	}
+}
+
+// CursorGoto handles an EventCursorGoto at the given absolute terminal row
+// and column: it focuses whichever window's Rect (as last computed by
+// Resize) contains the click and translates the coordinates into that
+// window's own viewport before calling its cursorGoto, so clicking into a
+// window other than the one at the terminal's top-left corner positions
+// the cursor correctly instead of against the wrong offset entirely.
+func (l *Layout) CursorGoto(row, col int) {
+	for i, r := range l.rects {
+		if row >= r.Y && row < r.Y+r.Height && col >= r.X && col < r.X+r.Width {
+			l.focus = i
+			l.windows[i].cursorGoto(row-r.Y, col-r.X)
+			return
+		}
+	}
+}
+
+// lockScroll keeps every window in a diff layout showing the same offset
+// as the focused window, so scrolling one scrolls all of them together.
+func (l *Layout) lockScroll() {
+	offset := l.windows[l.focus].offset
+	for _, w := range l.windows {
+		w.offset = offset
+	}
+}
+
+// States returns one State per window, in the same left-to-right or
+// top-to-bottom order as Resize's rectangles, with DiffMask filled in
+// when the layout is a diff view.
+func (l *Layout) States() ([]State, error) {
+	if l.diff {
+		l.lockScroll()
+	}
+	states := make([]State, len(l.windows))
+	for i, w := range l.windows {
+		s, err := w.State()
+		if err != nil {
+			return nil, err
+		}
+		s.Rect = l.rects[i]
+		states[i] = s
+	}
+	if l.diff {
+		fillDiffMask(states)
+	}
+	return states, nil
+}
+
+// fillDiffMask marks, for every state in a diff layout, the offsets at which
+// some window's byte differs from the first window's byte, and every offset
+// past the end of the shortest window.
+func fillDiffMask(states []State) {
+	if len(states) < 2 {
+		return
+	}
+	n := states[0].Size
+	for _, s := range states[1:] {
+		if s.Size < n {
+			n = s.Size
+		}
+	}
+	for i := range states {
+		mask := make([]bool, states[i].Size)
+		for j := 0; j < n; j++ {
+			b := states[0].Bytes[j]
+			for _, s := range states[1:] {
+				if s.Bytes[j] != b {
+					mask[j] = true
+					break
+				}
+			}
+		}
+		for j := n; j < states[i].Size; j++ {
+			mask[j] = true
+		}
+		states[i].DiffMask = mask
+	}
+}
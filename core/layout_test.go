@@ -0,0 +1,54 @@
+package core
+
+import "testing"
+
+func TestLayoutResizeVerticalSplit(t *testing.T) {
+	l := &Layout{windows: []*Window{{}, {}, {}}, split: splitVertical}
+	l.Resize(100, 40)
+
+	want := []Rect{{X: 0, Y: 0, Width: 33, Height: 40}, {X: 33, Y: 0, Width: 33, Height: 40}, {X: 66, Y: 0, Width: 34, Height: 40}}
+	for i, r := range want {
+		if l.rects[i] != r {
+			t.Errorf("rect %d = %+v, want %+v", i, l.rects[i], r)
+		}
+	}
+}
+
+func TestLayoutCursorGotoTranslatesAndFocuses(t *testing.T) {
+	w0 := &Window{width: 16, length: 1000, height: 10}
+	w1 := &Window{width: 16, length: 1000, height: 10}
+	l := &Layout{windows: []*Window{w0, w1}, split: splitVertical}
+	l.Resize(32, 10)
+
+	// A click inside the second window's Rect must move that window's
+	// cursor using coordinates local to it, not the absolute row/col, and
+	// must focus it rather than leaving window 0 focused.
+	l.CursorGoto(2, 16+3*3)
+
+	if l.focus != 1 {
+		t.Fatalf("focus = %d, want 1", l.focus)
+	}
+	if want := int64(2*16 + 3); w1.cursor != want {
+		t.Errorf("w1.cursor = %d, want %d", w1.cursor, want)
+	}
+	if w0.cursor != 0 {
+		t.Errorf("w0.cursor = %d, want 0 (untouched)", w0.cursor)
+	}
+}
+
+func TestFillDiffMask(t *testing.T) {
+	states := []State{
+		{Bytes: []byte{1, 2, 3}, Size: 3},
+		{Bytes: []byte{1, 9, 3}, Size: 3},
+	}
+	fillDiffMask(states)
+
+	want := []bool{false, true, false}
+	for i, s := range states {
+		for j, m := range want {
+			if s.DiffMask[j] != m {
+				t.Errorf("states[%d].DiffMask[%d] = %v, want %v", i, j, s.DiffMask[j], m)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import "fmt"
+
+// maxMacroDepth bounds macro nesting (a recorded macro that replays
+// another macro, and so on) so a cyclic recording can't hang the editor.
+const maxMacroDepth = 64
+
+// pressMacro intercepts the keys that drive macro recording and replay
+// ("q"/"q<reg>" and "@"/"@<reg>") before they reach the normal dispatch
+// table, since the register name is arbitrary (a-z) and can't be expressed
+// as a static binding the way other commands are. It reports whether k was
+// consumed as part of that handling.
+func (km *KeyManager) pressMacro(k Key) (Event, bool) {
+	if km.pending != "" {
+		quote := km.pending
+		km.pending = ""
+		if quote == "q" {
+			km.recording = new([]Key)
+			km.recordReg = k
+		} else {
+			if km.recording != nil {
+				*km.recording = append(*km.recording, k)
+			}
+			return Event{Type: EventReplayMacro, Key: k}, true
+		}
+		return Event{Type: EventNop}, true
+	}
+
+	if km.recording != nil {
+		if k == "q" {
+			if km.registers == nil {
+				km.registers = make(map[Key][]Key)
+			}
+			km.registers[km.recordReg] = *km.recording
+			km.recording = nil
+			return Event{Type: EventNop}, true
+		}
+		// The stop key above is the only key excluded from the recording;
+		// everything else, including keys that also drive macro playback,
+		// is written to the register verbatim.
+		*km.recording = append(*km.recording, k)
+	}
+
+	if len(km.keys) == 0 && (k == "q" || k == "@") {
+		km.pending = k
+		return Event{Type: EventNop}, true
+	}
+
+	return Event{}, false
+}
+
+// Registers returns the recorded macro registers, keyed by register name,
+// so they can be persisted between sessions or listed by :registers.
+func (km *KeyManager) Registers() map[Key][]Key {
+	return km.registers
+}
+
+// SetRegister installs a macro register directly, e.g. when restoring
+// registers persisted from a previous session.
+func (km *KeyManager) SetRegister(reg Key, keys []Key) {
+	if km.registers == nil {
+		km.registers = make(map[Key][]Key)
+	}
+	km.registers[reg] = keys
+}
+
+// Replay feeds the keys recorded in register reg back through Press, in
+// order, calling exec for each resulting Event. Because Press already
+// normalizes counts and prefixes, replay naturally re-applies
+// count-prefixed commands exactly as they were recorded. "@" replays
+// whichever register was last played (vim's @@). exec should return false
+// to abort the replay early, e.g. when an event would move the cursor past
+// the end of the file; Replay then stops without feeding the remaining
+// keys. Replay returns an error if macros are nested more than
+// maxMacroDepth deep, which guards against a register that replays itself.
+func (km *KeyManager) Replay(reg Key, exec func(Event) bool) error {
+	if reg == "@" {
+		reg = km.lastReg
+	}
+	if reg == "" {
+		return nil
+	}
+	if km.depth >= maxMacroDepth {
+		return fmt.Errorf("core: macro nesting exceeds %d levels", maxMacroDepth)
+	}
+	km.lastReg = reg
+	km.depth++
+	defer func() { km.depth-- }()
+	for _, k := range km.registers[reg] {
+		if !exec(km.Press(k)) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import "testing"
+
+func TestMacroRecordAndReplay(t *testing.T) {
+	km := NewMacroKeyManager(false)
+	km.Register(EventCursorRight, "l")
+
+	km.Press("q") // "q" + register name starts recording
+	km.Press("a")
+	km.Press("l")
+	km.Press("l")
+	km.Press("q") // "q" while recording stops it
+
+	got := km.Registers()["a"]
+	want := []Key{"l", "l"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	var events []EventType
+	if err := km.Replay("a", func(ev Event) bool {
+		events = append(events, ev.Type)
+		return true
+	}); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if len(events) != 2 || events[0] != EventCursorRight || events[1] != EventCursorRight {
+		t.Errorf("got %v, want two EventCursorRight", events)
+	}
+}
+
+func TestMacroReplayAtSign(t *testing.T) {
+	km := NewMacroKeyManager(false)
+	km.SetRegister("a", []Key{"l"})
+	km.Register(EventCursorRight, "l")
+
+	if err := km.Replay("a", func(Event) bool { return true }); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	// "@@" (reg == "@") replays whichever register played last.
+	var n int
+	if err := km.Replay("@", func(Event) bool { n++; return true }); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("got %d replayed keys, want 1", n)
+	}
+}
+
+func TestMacroReplayDepthCap(t *testing.T) {
+	km := NewMacroKeyManager(false)
+	km.SetRegister("a", []Key{"l"})
+	km.depth = maxMacroDepth
+
+	if err := km.Replay("a", func(Event) bool { return true }); err == nil {
+		t.Error("expected an error when already at the max macro nesting depth")
+	}
+}
+
+func TestMacroOnlyInterceptedOnMacroKeyManager(t *testing.T) {
+	km := NewKeyManager(false) // not NewMacroKeyManager
+	km.Register(EventCursorUp, "q")
+
+	if ev := km.Press("q"); ev.Type != EventCursorUp {
+		t.Errorf("got %v, want EventCursorUp: \"q\" must dispatch normally on a non-macro KeyManager", ev.Type)
+	}
+}
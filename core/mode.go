@@ -0,0 +1,14 @@
+package core
+
+// Mode represents the mode of the editor.
+type Mode int
+
+// Modes.
+const (
+	ModeNormal Mode = iota
+	ModeInsert
+	ModeReplace
+	ModeCmdline
+	ModeVisual
+	ModeVisualLine
+)
@@ -0,0 +1,35 @@
+package core
+
+// Rect describes a window's viewport within the terminal, in character
+// cells, so a UI backend can render several windows side by side.
+type Rect struct {
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// State represents the current state of a window, passed to UI.Redraw.
+type State struct {
+	Name   string
+	Width  int
+	Offset int64
+	Cursor int64
+	Bytes  []byte
+	Size   int
+	Length int64
+	Mode   Mode
+
+	// SelStart and SelEnd bound the current visual selection (inclusive)
+	// and are only meaningful when Mode is ModeVisual or ModeVisualLine.
+	SelStart int64
+	SelEnd   int64
+
+	// Rect is this window's viewport, filled in by Layout.States.
+	Rect Rect
+
+	// DiffMask marks, for each byte in Bytes, whether it differs from the
+	// byte at the same offset in the other window of a :diff layout. It is
+	// nil outside of diff mode.
+	DiffMask []bool
+}
@@ -1,10 +1,22 @@
 package core
 
+// Capabilities describes the optional features a UI backend supports, so
+// the editor can adjust its behavior (e.g. fall back to 256 colors) without
+// knowing which backend is in use.
+type Capabilities struct {
+	TrueColor bool
+	Mouse     bool
+	AltScreen bool
+}
+
 // UI defines the required user interface for the editor.
 type UI interface {
 	Init(ch chan<- Event) error
 	Start(km map[Mode]*KeyManager) error
 	Height() int
-	Redraw(state State) error
+	// Redraw draws one state per visible window, each carrying the Rect
+	// of the terminal it should be drawn into.
+	Redraw(states []State) error
+	Capabilities() Capabilities
 	Close() error
 }
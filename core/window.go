@@ -22,6 +22,8 @@ type Window struct {
 	length   int64
 	stack    []position
 	mode     Mode
+	selStart int64
+	register []byte
 }
 
 type position struct {
@@ -69,7 +71,7 @@ func (w *Window) State() (State, error) {
 	if err != nil {
 		return State{}, err
 	}
-	return State{
+	state := State{
 		Name:   w.basename,
 		Width:  int(w.width),
 		Offset: w.offset,
@@ -78,7 +80,11 @@ func (w *Window) State() (State, error) {
 		Size:   n,
 		Length: w.length,
 		Mode:   w.mode,
-	}, nil
+	}
+	if w.mode == ModeVisual || w.mode == ModeVisualLine {
+		state.SelStart, state.SelEnd = w.selRange()
+	}
+	return state, nil
 }
 
 // Close the window.
@@ -89,6 +95,18 @@ func (w *Window) Close() error {
 	return w.buffer.Close()
 }
 
+// resize sets the number of visible rows, called by Layout.Resize when the
+// terminal size changes or a window is (re)split.
+func (w *Window) resize(height int) {
+	w.height = int64(height)
+}
+
+// Width returns the window's configured bytes-per-row width, so a new
+// window opened into the same Layout (by :vsplit/:split/:diff) can match it.
+func (w *Window) Width() int64 {
+	return w.width
+}
+
 func (w *Window) cursorUp(count int64) {
 	w.cursor -= util.MinInt64(util.MaxInt64(count, 1), w.cursor/w.width) * w.width
 	if w.cursor < w.offset {
@@ -246,6 +264,16 @@ func (w *Window) jumpBack() {
 	w.stack = w.stack[:len(w.stack)-1]
 }
 
+// cursorGoto moves the cursor to the byte under a mouse click in the hex
+// pane, given the row and column the click landed on *within this window's
+// own viewport*, i.e. already translated by Layout.CursorGoto from the
+// absolute terminal coordinates the UI backend reports. Each byte occupies
+// three columns ("xx "), hence the division by 3.
+func (w *Window) cursorGoto(row, col int) {
+	cursor := w.offset + int64(row)*w.width + int64(col)/3
+	w.cursor = util.MinInt64(util.MaxInt64(cursor, 0), util.MaxInt64(w.length, 1)-1)
+}
+
 func (w *Window) startInsert() {
 	w.mode = ModeInsert
 }
@@ -253,3 +281,109 @@ func (w *Window) startInsert() {
 func (w *Window) exitInsert() {
 	w.mode = ModeNormal
 }
+
+func (w *Window) startVisual() {
+	w.selStart = w.cursor
+	w.mode = ModeVisual
+}
+
+func (w *Window) startVisualLine() {
+	w.selStart = w.cursor
+	w.mode = ModeVisualLine
+}
+
+func (w *Window) exitVisual() {
+	w.mode = ModeNormal
+}
+
+// selRange returns the inclusive byte range currently selected, ordered so
+// that start <= end regardless of which direction the cursor moved away
+// from the anchor. In ModeVisualLine it is rounded out to whole rows.
+func (w *Window) selRange() (int64, int64) {
+	start, end := w.selStart, w.cursor
+	if start > end {
+		start, end = end, start
+	}
+	if w.mode == ModeVisualLine {
+		start -= start % w.width
+		end = util.MinInt64(end-end%w.width+w.width-1, util.MaxInt64(w.length, 1)-1)
+	}
+	return start, end
+}
+
+// inVisual reports whether a visual selection is active. yank,
+// deleteSelection and replaceSelection must not act outside of it: they
+// are only reachable through the visual-mode KeyManager, but this guard
+// keeps selRange from ever being evaluated against a stale or zero
+// selStart if that ever changes.
+func (w *Window) inVisual() bool {
+	return w.mode == ModeVisual || w.mode == ModeVisualLine
+}
+
+// yank copies the selected range into the register without modifying the
+// buffer, then returns to normal mode with the cursor at the start of the
+// former selection.
+func (w *Window) yank() {
+	if !w.inVisual() {
+		return
+	}
+	start, end := w.selRange()
+	_, bytes, err := w.readBytes(start, int(end-start+1))
+	if err != nil {
+		return
+	}
+	w.register = bytes
+	w.cursor = start
+	w.exitVisual()
+}
+
+// deleteSelection cuts the selected range out of the buffer, copying it
+// into the register first so it can be pasted back with paste.
+func (w *Window) deleteSelection() {
+	if !w.inVisual() {
+		return
+	}
+	start, end := w.selRange()
+	size := end - start + 1
+	_, bytes, err := w.readBytes(start, int(size))
+	if err != nil {
+		return
+	}
+	if err := w.buffer.Cut(start, size); err != nil {
+		return
+	}
+	w.register = bytes
+	w.length -= size
+	w.cursor = util.MinInt64(start, util.MaxInt64(w.length, 1)-1)
+	if w.cursor < w.offset {
+		w.offset = w.cursor / w.width * w.width
+	}
+	w.exitVisual()
+}
+
+// replaceSelection deletes the selected range and drops into insert mode
+// at the cursor, the same as vim's "c" over a visual selection.
+func (w *Window) replaceSelection() {
+	if !w.inVisual() {
+		return
+	}
+	w.deleteSelection()
+	w.mode = ModeInsert
+}
+
+// paste inserts the register contents at the cursor, or just after it when
+// after is true, mirroring vim's p/P.
+func (w *Window) paste(after bool) {
+	if len(w.register) == 0 {
+		return
+	}
+	pos := w.cursor
+	if after {
+		pos = util.MinInt64(w.cursor+1, w.length)
+	}
+	if err := w.buffer.Insert(pos, w.register); err != nil {
+		return
+	}
+	w.length += int64(len(w.register))
+	w.cursor = pos
+}
@@ -0,0 +1,42 @@
+package core
+
+import "testing"
+
+func TestWindowSelRangeOrdering(t *testing.T) {
+	w := &Window{width: 16, length: 100, mode: ModeVisual, selStart: 40, cursor: 10}
+	start, end := w.selRange()
+	if start != 10 || end != 40 {
+		t.Errorf("got (%d, %d), want (10, 40) regardless of which end the cursor moved away from", start, end)
+	}
+}
+
+func TestWindowSelRangeVisualLineRounding(t *testing.T) {
+	w := &Window{width: 16, length: 100, mode: ModeVisualLine, selStart: 5, cursor: 20}
+	start, end := w.selRange()
+	if start != 0 || end != 31 {
+		t.Errorf("got (%d, %d), want (0, 31): visual-line selection rounds out to whole rows", start, end)
+	}
+}
+
+func TestWindowVisualOpsNoopOutsideVisualMode(t *testing.T) {
+	w := &Window{width: 16, length: 100, mode: ModeNormal, selStart: 0, cursor: 40}
+
+	// These must return before touching w.buffer, which is nil here: in
+	// Normal mode selStart is never set to the cursor, and a real buffer
+	// access would panic on the nil pointer if the guard were missing.
+	w.yank()
+	w.deleteSelection()
+	w.replaceSelection()
+
+	if w.mode != ModeNormal || w.cursor != 40 || w.length != 100 {
+		t.Errorf("Window state changed despite not being in a visual mode: %+v", w)
+	}
+}
+
+func TestWindowCursorGotoLocalCoordinates(t *testing.T) {
+	w := &Window{width: 16, length: 1000, height: 10}
+	w.cursorGoto(2, 3*3)
+	if want := int64(2*16 + 3); w.cursor != want {
+		t.Errorf("got cursor %d, want %d", w.cursor, want)
+	}
+}
@@ -0,0 +1,85 @@
+// Package macro persists core.KeyManager macro registers to disk between
+// sessions, and formats them for the :registers cmdline command.
+package macro
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/bed/core"
+)
+
+// Path returns the location of the registers file, honoring
+// $XDG_STATE_HOME.
+func Path() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "bed", "registers.json")
+}
+
+// Load reads registers persisted at path into km. A missing file is not an
+// error: it simply leaves km without any restored registers, the same as
+// a fresh install.
+func Load(path string, km *core.KeyManager) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var registers map[core.Key][]core.Key
+	if err := json.Unmarshal(data, &registers); err != nil {
+		return err
+	}
+	for reg, keys := range registers {
+		km.SetRegister(reg, keys)
+	}
+	return nil
+}
+
+// Save persists km's registers to path, creating its parent directory if
+// it doesn't already exist.
+func Save(path string, km *core.KeyManager) error {
+	data, err := json.MarshalIndent(km.Registers(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// List formats km's registers for the :registers cmdline command, one
+// register per line as "<name>  <space-joined keys>", sorted by register
+// name so the output is deterministic.
+func List(km *core.KeyManager) string {
+	registers := km.Registers()
+	regs := make([]string, 0, len(registers))
+	for reg := range registers {
+		regs = append(regs, string(reg))
+	}
+	sort.Strings(regs)
+
+	var b strings.Builder
+	for _, reg := range regs {
+		keys := registers[core.Key(reg)]
+		strs := make([]string, len(keys))
+		for i, k := range keys {
+			strs[i] = string(k)
+		}
+		fmt.Fprintf(&b, "%s  %s\n", reg, strings.Join(strs, " "))
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package macro
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/itchyny/bed/core"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	km := core.NewKeyManager(false)
+	km.SetRegister("a", []core.Key{"i", "x", "escape"})
+
+	path := filepath.Join(t.TempDir(), "registers.json")
+	if err := Save(path, km); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded := core.NewKeyManager(false)
+	if err := Load(path, loaded); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got := loaded.Registers()["a"]
+	want := []core.Key{"i", "x", "escape"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestLoadMissingFileIsNotAnError(t *testing.T) {
+	km := core.NewKeyManager(false)
+	if err := Load(filepath.Join(t.TempDir(), "missing.json"), km); err != nil {
+		t.Errorf("Load of a missing file should not error, got %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	km := core.NewKeyManager(false)
+	km.SetRegister("a", []core.Key{"i", "x"})
+
+	out := List(km)
+	if out != "a  i x\n" {
+		t.Errorf("got %q, want %q", out, "a  i x\n")
+	}
+}
+
+func TestListIsSortedByRegisterName(t *testing.T) {
+	km := core.NewKeyManager(false)
+	km.SetRegister("z", []core.Key{"x"})
+	km.SetRegister("a", []core.Key{"y"})
+	km.SetRegister("m", []core.Key{"z"})
+
+	want := "a  y\nm  z\nz  x\n"
+	for i := 0; i < 5; i++ {
+		if out := List(km); out != want {
+			t.Fatalf("got %q, want %q (run %d)", out, want, i)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Package rc loads user-defined key bindings from the bed rc file so
+// bindings can be customized without recompiling.
+//
+// The format is one binding per line:
+//
+//	mode keys event [count]
+//
+// mode is one of normal, insert, replace, cmdline, visual, visualline.
+// keys is a comma-separated key sequence, e.g. g,g for the two-keystroke
+// "gg" binding. event is an EventType name exactly as produced by its
+// String method, e.g. EventJumpTo. count is currently accepted but unused,
+// reserved for events that want a fixed count baked into the binding.
+// Blank lines and lines starting with # are ignored.
+package rc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/itchyny/bed/core"
+)
+
+// Path returns the location of the rc file, honoring $XDG_CONFIG_HOME.
+func Path() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "bed", "bedrc")
+}
+
+var modeByName = map[string]core.Mode{
+	"normal":     core.ModeNormal,
+	"insert":     core.ModeInsert,
+	"replace":    core.ModeReplace,
+	"cmdline":    core.ModeCmdline,
+	"visual":     core.ModeVisual,
+	"visualline": core.ModeVisualLine,
+}
+
+// ModeByName resolves a mode name as it appears in the rc file (and in the
+// :map/:unmap cmdline commands) to a core.Mode.
+func ModeByName(name string) (core.Mode, bool) {
+	mode, ok := modeByName[name]
+	return mode, ok
+}
+
+// Binding is one parsed line of the rc file.
+type Binding struct {
+	Mode  core.Mode
+	Keys  []core.Key
+	Event core.EventType
+}
+
+// Load reads and parses the rc file at path. A missing file is not an
+// error: it simply yields no bindings, so a fresh install behaves exactly
+// like today without one.
+func Load(path string) ([]Binding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+func parse(r io.Reader) ([]Binding, error) {
+	var bindings []Binding
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		b, err := ParseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("bedrc:%d: %w", lineNo, err)
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings, scanner.Err()
+}
+
+// ParseLine parses a single "mode keys event [count]" line, the same
+// format used by the rc file and by the :map cmdline command.
+func ParseLine(line string) (Binding, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 {
+		return Binding{}, fmt.Errorf("expected \"mode keys event\", got %q", line)
+	}
+	mode, ok := ModeByName(fields[0])
+	if !ok {
+		return Binding{}, fmt.Errorf("unknown mode %q", fields[0])
+	}
+	event, ok := core.EventTypeFromString(fields[2])
+	if !ok {
+		return Binding{}, fmt.Errorf("unknown event %q", fields[2])
+	}
+	parts := strings.Split(fields[1], ",")
+	keys := make([]core.Key, len(parts))
+	for i, k := range parts {
+		keys[i] = core.Key(k)
+	}
+	return Binding{Mode: mode, Keys: keys, Event: event}, nil
+}
+
+// Apply installs each parsed Binding into the matching KeyManager, erroring
+// out instead of creating an unreachable prefix (e.g. a new "g" binding
+// shadowing the existing "g g" page-top binding).
+func Apply(kms map[core.Mode]*core.KeyManager, bindings []Binding) error {
+	for _, b := range bindings {
+		km, ok := kms[b.Mode]
+		if !ok {
+			return fmt.Errorf("bedrc: no key manager for mode %v", b.Mode)
+		}
+		if km.WouldShadow(b.Keys...) {
+			return fmt.Errorf("bedrc: %v would shadow an existing binding in this mode", b.Keys)
+		}
+		km.Replace(b.Event, b.Keys...)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package rc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/itchyny/bed/core"
+)
+
+func TestParseLine(t *testing.T) {
+	b, err := ParseLine("normal g,g EventPageTop")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.Mode != core.ModeNormal {
+		t.Errorf("Mode = %v, want ModeNormal", b.Mode)
+	}
+	if b.Event != core.EventPageTop {
+		t.Errorf("Event = %v, want EventPageTop", b.Event)
+	}
+	want := []core.Key{"g", "g"}
+	if len(b.Keys) != len(want) || b.Keys[0] != want[0] || b.Keys[1] != want[1] {
+		t.Errorf("Keys = %v, want %v", b.Keys, want)
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	cases := []string{
+		"normal g",
+		"bogus g EventPageTop",
+		"normal g Bogus",
+	}
+	for _, line := range cases {
+		if _, err := ParseLine(line); err == nil {
+			t.Errorf("ParseLine(%q): expected an error", line)
+		}
+	}
+}
+
+func TestParseSkipsBlankAndCommentLines(t *testing.T) {
+	r := strings.NewReader("\n# a comment\nnormal g,g EventPageTop\n")
+	bindings, err := parse(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bindings) != 1 {
+		t.Fatalf("got %d bindings, want 1", len(bindings))
+	}
+}
+
+func TestParseWrapsLineNumber(t *testing.T) {
+	r := strings.NewReader("normal g,g EventPageTop\nnormal g\n")
+	_, err := parse(r)
+	if err == nil || !strings.Contains(err.Error(), "bedrc:2:") {
+		t.Errorf("got %v, want an error mentioning line 2", err)
+	}
+}
@@ -0,0 +1,64 @@
+// +build dummy
+
+package tui
+
+import "github.com/itchyny/bed/core"
+
+// UI is a headless implementation of core.UI, used in tests that need to
+// drive the event/redraw paths without a real terminal attached.
+type UI struct {
+	ch      chan<- core.Event
+	height  int
+	redraws [][]core.State
+}
+
+// New creates a new dummy UI.
+func New() *UI {
+	return &UI{height: 20}
+}
+
+// Init records the event channel; no real terminal is touched.
+func (ui *UI) Init(ch chan<- core.Event) error {
+	ui.ch = ch
+	return nil
+}
+
+// Start is a no-op for the dummy backend.
+func (ui *UI) Start(km map[core.Mode]*core.KeyManager) error {
+	return nil
+}
+
+// Height returns the fixed height configured for the dummy backend.
+func (ui *UI) Height() int {
+	return ui.height
+}
+
+// Redraw records the states instead of drawing them, so tests can assert
+// on what would have been shown.
+func (ui *UI) Redraw(states []core.State) error {
+	ui.redraws = append(ui.redraws, states)
+	return nil
+}
+
+// Capabilities reports the full feature set so tests can exercise every
+// code path gated behind a capability check.
+func (ui *UI) Capabilities() core.Capabilities {
+	return core.Capabilities{TrueColor: true, Mouse: true, AltScreen: true}
+}
+
+// Close is a no-op for the dummy backend.
+func (ui *UI) Close() error {
+	return nil
+}
+
+// Press feeds a key through the given key manager, emulating a keystroke
+// for headless tests.
+func (ui *UI) Press(km *core.KeyManager, k core.Key) core.Event {
+	return km.Press(k)
+}
+
+// Redraws returns every slice of states passed to Redraw so far, for test
+// assertions.
+func (ui *UI) Redraws() [][]core.State {
+	return ui.redraws
+}
@@ -0,0 +1,42 @@
+// +build dummy
+
+package tui
+
+import (
+	"testing"
+
+	"github.com/itchyny/bed/core"
+)
+
+func TestDummyUIPressAndRedraw(t *testing.T) {
+	ui := New()
+	ch := make(chan core.Event, 1)
+	if err := ui.Init(ch); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	km := core.NewKeyManager(false)
+	km.Register(core.EventCursorRight, "l")
+	if ev := ui.Press(km, "l"); ev.Type != core.EventCursorRight {
+		t.Errorf("got %v, want EventCursorRight", ev.Type)
+	}
+
+	states := []core.State{{Name: "a"}}
+	if err := ui.Redraw(states); err != nil {
+		t.Fatalf("Redraw: %v", err)
+	}
+	redraws := ui.Redraws()
+	if len(redraws) != 1 || len(redraws[0]) != 1 || redraws[0][0].Name != "a" {
+		t.Errorf("got %v, want one recorded redraw of %v", redraws, states)
+	}
+
+	if h := ui.Height(); h != 20 {
+		t.Errorf("Height() = %d, want 20", h)
+	}
+	if caps := ui.Capabilities(); !caps.Mouse || !caps.TrueColor || !caps.AltScreen {
+		t.Errorf("Capabilities() = %+v, want every capability enabled", caps)
+	}
+	if err := ui.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+// +build tcell
+
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/itchyny/bed/core"
+)
+
+// UI is the tcell-backed implementation of core.UI. Unlike the termbox
+// backend it supports 24-bit color and mouse events on terminals that
+// advertise them.
+type UI struct {
+	screen tcell.Screen
+	ch     chan<- core.Event
+}
+
+// New creates a new tcell UI.
+func New() *UI {
+	return &UI{}
+}
+
+// Init initializes the tcell screen and starts the event loop.
+func (ui *UI) Init(ch chan<- core.Event) error {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return err
+	}
+	if err := screen.Init(); err != nil {
+		return err
+	}
+	screen.EnableMouse()
+	ui.screen = screen
+	ui.ch = ch
+	go ui.eventLoop()
+	return nil
+}
+
+func (ui *UI) eventLoop() {
+	for {
+		switch ev := ui.screen.PollEvent().(type) {
+		case *tcell.EventKey:
+			ui.ch <- core.Event{Type: core.EventNop, Key: core.Key(ev.Name())}
+		case *tcell.EventMouse:
+			if k, ok := mouseKey(ev.Buttons()); ok {
+				col, row := ev.Position()
+				ui.ch <- core.Event{Type: core.EventNop, Key: k, MouseRow: row, MouseCol: col}
+			}
+		case *tcell.EventResize:
+			ui.screen.Sync()
+		case nil:
+			return
+		}
+	}
+}
+
+// Start does nothing with the key managers and returns nil. Dispatch
+// happens in the caller's main loop; tcell only supplies raw keys here.
+func (ui *UI) Start(km map[core.Mode]*core.KeyManager) error {
+	return nil
+}
+
+// Height returns the number of visible rows.
+func (ui *UI) Height() int {
+	_, height := ui.screen.Size()
+	return height
+}
+
+// Redraw draws one state per window, each offset by its own Rect so
+// multiple windows can be shown side by side.
+func (ui *UI) Redraw(states []core.State) error {
+	ui.screen.Clear()
+	for _, state := range states {
+		for i := 0; i < state.Size; i++ {
+			style := tcell.StyleDefault
+			if state.DiffMask != nil && state.DiffMask[i] {
+				style = style.Foreground(tcell.ColorRed)
+			}
+			digits := formatByte(state.Bytes[i])
+			row, col := i/state.Width, i%state.Width
+			ui.screen.SetContent(state.Rect.X+col*3, state.Rect.Y+row, rune(digits[0]), nil, style)
+			ui.screen.SetContent(state.Rect.X+col*3+1, state.Rect.Y+row, rune(digits[1]), nil, style)
+		}
+	}
+	ui.screen.Show()
+	return nil
+}
+
+// Capabilities reports that the tcell backend supports true color, mouse
+// input and the alternate screen buffer.
+func (ui *UI) Capabilities() core.Capabilities {
+	return core.Capabilities{TrueColor: true, Mouse: true, AltScreen: true}
+}
+
+// Close shuts down the tcell screen.
+func (ui *UI) Close() error {
+	ui.screen.Fini()
+	return nil
+}
+
+// mouseKey maps the tcell button mask that triggered a mouse event to the
+// synthetic key fed into the KeyManager pipeline.
+func mouseKey(buttons tcell.ButtonMask) (core.Key, bool) {
+	switch {
+	case buttons&tcell.Button1 != 0:
+		return "mouse-left", true
+	case buttons&tcell.Button2 != 0:
+		return "mouse-right", true
+	case buttons&tcell.WheelUp != 0:
+		return "wheel-up", true
+	case buttons&tcell.WheelDown != 0:
+		return "wheel-down", true
+	default:
+		return "", false
+	}
+}
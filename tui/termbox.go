@@ -0,0 +1,120 @@
+// +build !tcell,!dummy
+
+package tui
+
+import (
+	"github.com/nsf/termbox-go"
+
+	"github.com/itchyny/bed/core"
+)
+
+// UI is the termbox-backed implementation of core.UI. It is the default
+// backend: termbox-go is effectively unmaintained and caps out at 256
+// colors with no true color support, but it remains the most portable option.
+type UI struct {
+	ch     chan<- core.Event
+	height int
+}
+
+// New creates a new termbox UI.
+func New() *UI {
+	return &UI{}
+}
+
+// Init initializes termbox and starts the event loop.
+func (ui *UI) Init(ch chan<- core.Event) error {
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+	termbox.SetInputMode(termbox.InputEsc | termbox.InputMouse)
+	ui.ch = ch
+	_, ui.height = termbox.Size()
+	go ui.eventLoop()
+	return nil
+}
+
+func (ui *UI) eventLoop() {
+	for {
+		switch ev := termbox.PollEvent(); ev.Type {
+		case termbox.EventKey:
+			ui.ch <- core.Event{Type: core.EventNop, Key: eventToKey(ev)}
+		case termbox.EventMouse:
+			if k, ok := mouseKey(ev.Key); ok {
+				ui.ch <- core.Event{Type: core.EventNop, Key: k, MouseRow: ev.MouseY, MouseCol: ev.MouseX}
+			}
+		case termbox.EventResize:
+			ui.height = ev.Height
+		case termbox.EventInterrupt:
+			return
+		}
+	}
+}
+
+// Start does nothing with the key managers and returns nil. Dispatch
+// happens in the caller's main loop; termbox only supplies raw keys here.
+func (ui *UI) Start(km map[core.Mode]*core.KeyManager) error {
+	return nil
+}
+
+// Height returns the number of visible rows.
+func (ui *UI) Height() int {
+	return ui.height
+}
+
+// Redraw draws one state per window, each offset by its own Rect so
+// multiple windows can be shown side by side.
+func (ui *UI) Redraw(states []core.State) error {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	for _, state := range states {
+		fg := termbox.ColorDefault
+		for i := 0; i < state.Size; i++ {
+			if state.DiffMask != nil && state.DiffMask[i] {
+				fg = termbox.ColorRed
+			} else {
+				fg = termbox.ColorDefault
+			}
+			digits := formatByte(state.Bytes[i])
+			row, col := i/state.Width, i%state.Width
+			termbox.SetCell(state.Rect.X+col*3, state.Rect.Y+row, rune(digits[0]), fg, termbox.ColorDefault)
+			termbox.SetCell(state.Rect.X+col*3+1, state.Rect.Y+row, rune(digits[1]), fg, termbox.ColorDefault)
+		}
+	}
+	return termbox.Flush()
+}
+
+// Capabilities reports what the termbox backend can do: no true color, but
+// mouse events are supported via InputMouse.
+func (ui *UI) Capabilities() core.Capabilities {
+	return core.Capabilities{TrueColor: false, Mouse: true, AltScreen: true}
+}
+
+// Close shuts down termbox.
+func (ui *UI) Close() error {
+	termbox.Interrupt()
+	termbox.Close()
+	return nil
+}
+
+func eventToKey(ev termbox.Event) core.Key {
+	if ev.Ch != 0 {
+		return core.Key(string(ev.Ch))
+	}
+	return core.Key(ev.Key.String())
+}
+
+// mouseKey maps the termbox button that triggered a mouse event to the
+// synthetic key fed into the KeyManager pipeline.
+func mouseKey(key termbox.Key) (core.Key, bool) {
+	switch key {
+	case termbox.MouseLeft:
+		return "mouse-left", true
+	case termbox.MouseRight:
+		return "mouse-right", true
+	case termbox.MouseWheelUp:
+		return "wheel-up", true
+	case termbox.MouseWheelDown:
+		return "wheel-down", true
+	default:
+		return "", false
+	}
+}
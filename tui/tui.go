@@ -0,0 +1,23 @@
+// Package tui implements core.UI on top of a terminal rendering library.
+//
+// The concrete backend is chosen at build time via build tags, following the
+// approach fzf takes for its src/tui package: the default build links
+// termbox-go, "-tags tcell" switches to tcell for 24-bit color and mouse
+// support on modern terminals, and "-tags dummy" links a headless backend
+// so the event/redraw paths can be exercised in tests without a real
+// terminal. Every backend file in this package defines a New function with
+// the same signature, so callers never need to know which one was compiled
+// in.
+package tui
+
+import "github.com/itchyny/bed/core"
+
+// hexDigits are shared by every backend's redraw routine.
+const hexDigits = "0123456789abcdef"
+
+// formatByte renders a single byte as two lowercase hex digits.
+func formatByte(b byte) [2]byte {
+	return [2]byte{hexDigits[b>>4], hexDigits[b&0x0f]}
+}
+
+var _ core.UI = (*UI)(nil)